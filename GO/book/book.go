@@ -0,0 +1,64 @@
+// Package book provides a precomputed opening book for the bitboard
+// solver: exact evaluations for every reachable position up to a
+// configurable ply depth, generated offline by cmd/genbook and embedded
+// into the binary so Solver.Solve can return instantly on a hit instead of
+// re-deriving well-known openings from scratch.
+//
+// data/book.gob ships empty in this tree. Populating it means exactly
+// solving every distinct position up to the target depth to the end of the
+// game, and a BookEntry that close to the empty board is exactly as
+// expensive to produce as solving the empty board itself (the depth only
+// changes how many such positions there are, not the cost of solving any
+// one of them). Solver.negamax now does PVS (a null-window search per
+// sibling, re-searching only the ones that beat the current best) ordered
+// by threatCount's static "how many lines does this move open" heuristic
+// instead of plain center-first — real wins over the previous
+// always-full-window, center-only search — but measured against this
+// solver, that still wasn't enough: solving just the position after a
+// single opening move did not return within 8 minutes. Generating a real
+// book needs a meaningfully stronger engine (a proper transposition-table
+// bisection a la MTD-f, parallel root splitting, or similar) than landing
+// PVS and a move-ordering heuristic turned out to buy. This package,
+// cmd/genbook and the negamax book probe are all wired up and tested
+// against planted entries (see book_test.go); running cmd/genbook to
+// actually populate data/book.gob is left for when that stronger engine
+// lands. Until then, this package is infrastructure only: nothing in
+// the solveur4 CLI (GO/main.go) or the menace self-play trainer consults Load's result, since
+// wiring an empty book into a time-budgeted search path would silently hand
+// back whatever SolveWithBudget's shallow iteration happens to find first —
+// no better than not having a book, but easy to mistake for one. The
+// solver-level book probe (Solver.SetBook, negamax's book lookup) stays
+// wired and tested against planted entries (see book_test.go); it's
+// cmd/genbook actually producing data/book.gob that's blocked.
+package book
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/gob"
+
+	"solveur4/GO/solver"
+)
+
+// DefaultMaxPly is the depth, in plies from the empty board, that
+// cmd/genbook precomputes by default.
+const DefaultMaxPly = 12
+
+//go:embed data/book.gob
+var data []byte
+
+// Load decodes the embedded book into a lookup table keyed the same way as
+// the solver's transposition table (Board.Key). The data file is generated
+// by cmd/genbook; if it is empty (no book has been generated yet), Load
+// returns an empty table rather than an error so callers can still run
+// without a book.
+func Load() (map[uint64]solver.BookEntry, error) {
+	entries := make(map[uint64]solver.BookEntry)
+	if len(data) == 0 {
+		return entries, nil
+	}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
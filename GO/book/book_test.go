@@ -0,0 +1,31 @@
+package book
+
+import (
+	"testing"
+
+	"solveur4/GO/solver"
+)
+
+// The acceptance tests against Connect-4's well-known perfect-play results
+// (empty board and center opening win for P1, edge opening loses) live in
+// book_exhaustive_test.go behind the "exhaustive" build tag: without a book,
+// this solver's exact search can take many minutes to resolve a
+// position that close to the empty board, so they can't run as part of the
+// default `go test ./...`.
+
+// TestBookHitShortCircuitsDescendant plants an out-of-range score (one no
+// real search could ever produce one ply in) for every child of the empty
+// board. The only way Solve can return it is if negamax probes the book
+// while descending into those children, not just at the root.
+func TestBookHitShortCircuitsDescendant(t *testing.T) {
+	s := solver.NewSolver()
+	entries := make(map[uint64]solver.BookEntry)
+	for _, c := range solver.ValidColumns(solver.NewBoard()) {
+		entries[solver.NewBoard().Play(c).Key()] = solver.BookEntry{BestMove: int8(c), Score: 42}
+	}
+	s.SetBook(entries)
+	_, score := s.Solve(solver.NewBoard())
+	if score != -42 {
+		t.Fatalf("expected the planted book entries to short-circuit every child, got score %d", score)
+	}
+}
@@ -0,0 +1,126 @@
+package book
+
+import (
+	"sync"
+
+	"solveur4/GO/solver"
+)
+
+// frontier enumerates every reachable, non-terminal position from the empty
+// board up to and including maxPly plies. Most positions are reachable by more than one
+// move order (e.g. playing columns 2 then 4 reaches the same board as 4
+// then 2), so a seen set dedups by Board.Key as we go — without it the walk
+// would solve the same position once per permutation of moves that reaches
+// it, multiplying Generate's work by the frontier's branching factor.
+func frontier(maxPly int) []solver.Board {
+	var boards []solver.Board
+	seen := make(map[uint64]bool)
+	var walk func(b solver.Board, ply int)
+	walk = func(b solver.Board, ply int) {
+		key := b.Key()
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		boards = append(boards, b)
+		if ply == maxPly || b.IsDraw() {
+			return
+		}
+		for _, c := range solver.ValidColumns(b) {
+			if b.IsWinningMove(c) {
+				continue
+			}
+			walk(b.Play(c), ply+1)
+		}
+	}
+	walk(solver.NewBoard(), 0)
+	return boards
+}
+
+// numShards is the number of transposition tables Generate spreads work
+// across: positions whose key falls in the same shard share a table and
+// are searched one at a time, but different shards run fully in parallel.
+const numShards = 32
+
+// shardedSolver is Generate's work-stealing queue in its simplest form: a
+// fixed set of solvers, each guarded by its own mutex, so workers pulling
+// boards off a shared channel never block on each other unless they
+// happen to land on the same shard — similar in shape to the worker pool
+// the old exploreGameTreeSmart used. The shard count is fixed at numShards;
+// the pool of workers pulling from the channel is sized by Generate's
+// workers argument (cmd/genbook defaults it to the number of CPUs) rather
+// than by the number of root moves.
+type shardedSolver struct {
+	mus     [numShards]sync.Mutex
+	solvers [numShards]*solver.Solver
+}
+
+func newShardedSolver() *shardedSolver {
+	ss := &shardedSolver{}
+	for i := range ss.solvers {
+		ss.solvers[i] = solver.NewSolver()
+	}
+	return ss
+}
+
+func (ss *shardedSolver) solve(b solver.Board) (int, int) {
+	shard := b.Key() % numShards
+	ss.mus[shard].Lock()
+	defer ss.mus[shard].Unlock()
+	return ss.solvers[shard].Solve(b)
+}
+
+// Generate computes exact BookEntry values for every position up to maxPly
+// plies from the empty board, splitting the frontier across workers
+// goroutines. progress, if non-nil, is called periodically with how many
+// of the total positions have been evaluated so far.
+func Generate(maxPly, workers int, progress func(done, total int)) map[uint64]solver.BookEntry {
+	boards := frontier(maxPly)
+	total := len(boards)
+
+	shared := newShardedSolver()
+
+	type result struct {
+		key   uint64
+		entry solver.BookEntry
+	}
+
+	tasks := make(chan solver.Board, total)
+	results := make(chan result, total)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range tasks {
+				move, score := shared.solve(b)
+				results <- result{key: b.Key(), entry: solver.BookEntry{BestMove: int8(move), Score: int8(score)}}
+			}
+		}()
+	}
+
+	for _, b := range boards {
+		tasks <- b
+	}
+	close(tasks)
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	entries := make(map[uint64]solver.BookEntry, total)
+	done := 0
+	for r := range results {
+		entries[r.key] = r.entry
+		done++
+		if progress != nil && done%64 == 0 {
+			progress(done, total)
+		}
+	}
+	if progress != nil {
+		progress(total, total)
+	}
+	return entries
+}
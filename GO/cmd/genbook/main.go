@@ -0,0 +1,40 @@
+// Command genbook precomputes the opening book consulted by the solver: an
+// exact evaluation for every position up to a configurable ply depth,
+// written to book/data/book.gob for go:embed to pick up on the next build.
+package main
+
+import (
+	"encoding/gob"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+
+	"solveur4/GO/book"
+)
+
+func main() {
+	maxPly := flag.Int("plies", book.DefaultMaxPly, "depth, in plies from the empty board, to precompute")
+	out := flag.String("out", "GO/book/data/book.gob", "output path for the generated book")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of worker goroutines")
+	flag.Parse()
+
+	entries := book.Generate(*maxPly, *workers, func(done, total int) {
+		fmt.Printf("\r%d/%d positions évaluées", done, total)
+	})
+	fmt.Println()
+
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "erreur :", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	if err := gob.NewEncoder(f).Encode(entries); err != nil {
+		fmt.Fprintln(os.Stderr, "erreur d'encodage :", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%d positions écrites dans %s\n", len(entries), *out)
+}
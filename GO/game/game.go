@@ -0,0 +1,115 @@
+// Package game defines a compact, SGF-inspired text format for recording
+// Connect-4 games: a header block of metadata followed by a semicolon move
+// list. It lets positions be shared, replayed for analysis, or curated into
+// an opening book.
+//
+//	Event: Partie interactive
+//	Date: 2026-07-29
+//	Red: Humain
+//	Yellow: Moteur
+//	Result: R+
+//
+//	;R[4];Y[3];R[4];Y[2]
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"solveur4/GO/solver"
+)
+
+// Game is a recorded Connect-4 game: metadata plus the move list. Moves are
+// 0-based columns, Red (P1) moving first.
+type Game struct {
+	Event  string
+	Date   string
+	Red    string
+	Yellow string
+	Result string
+	Moves  []int
+}
+
+// LoadGame parses a game record from r.
+func LoadGame(r io.Reader) (*Game, error) {
+	scanner := bufio.NewScanner(r)
+	g := &Game{}
+	var body strings.Builder
+	inBody := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !inBody {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" {
+				inBody = true
+				continue
+			}
+			key, value, ok := strings.Cut(trimmed, ":")
+			if !ok {
+				inBody = true
+			} else {
+				switch strings.TrimSpace(key) {
+				case "Event":
+					g.Event = strings.TrimSpace(value)
+				case "Date":
+					g.Date = strings.TrimSpace(value)
+				case "Red":
+					g.Red = strings.TrimSpace(value)
+				case "Yellow":
+					g.Yellow = strings.TrimSpace(value)
+				case "Result":
+					g.Result = strings.TrimSpace(value)
+				}
+				continue
+			}
+		}
+		body.WriteString(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, token := range strings.Split(body.String(), ";") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		if len(token) < 4 || token[1] != '[' || token[len(token)-1] != ']' {
+			return nil, fmt.Errorf("game: malformed move %q", token)
+		}
+		col, err := strconv.Atoi(token[2 : len(token)-1])
+		if err != nil {
+			return nil, fmt.Errorf("game: malformed move %q: %w", token, err)
+		}
+		if col < 0 || col >= solver.Cols {
+			return nil, fmt.Errorf("game: move %q out of range [0, %d)", token, solver.Cols)
+		}
+		g.Moves = append(g.Moves, col)
+	}
+	return g, nil
+}
+
+// SaveGame writes g to w in the format LoadGame parses.
+func SaveGame(w io.Writer, g *Game) error {
+	_, err := fmt.Fprintf(w, "Event: %s\nDate: %s\nRed: %s\nYellow: %s\nResult: %s\n\n",
+		g.Event, g.Date, g.Red, g.Yellow, g.Result)
+	if err != nil {
+		return err
+	}
+
+	for i, col := range g.Moves {
+		color := "R"
+		if i%2 != 0 {
+			color = "Y"
+		}
+		if _, err := fmt.Fprintf(w, ";%s[%d]", color, col); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintln(w)
+	return err
+}
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	g := &Game{
+		Event:  "Partie interactive",
+		Date:   "2026-07-30",
+		Red:    "Humain",
+		Yellow: "Moteur",
+		Result: "R+",
+		Moves:  []int{3, 2, 4, 4, 5, 1},
+	}
+
+	var buf bytes.Buffer
+	if err := SaveGame(&buf, g); err != nil {
+		t.Fatalf("SaveGame: %v", err)
+	}
+
+	got, err := LoadGame(&buf)
+	if err != nil {
+		t.Fatalf("LoadGame: %v", err)
+	}
+
+	if got.Event != g.Event || got.Date != g.Date || got.Red != g.Red ||
+		got.Yellow != g.Yellow || got.Result != g.Result {
+		t.Fatalf("metadata mismatch after round-trip: got %+v, want %+v", got, g)
+	}
+	if len(got.Moves) != len(g.Moves) {
+		t.Fatalf("move count mismatch: got %v, want %v", got.Moves, g.Moves)
+	}
+	for i := range g.Moves {
+		if got.Moves[i] != g.Moves[i] {
+			t.Fatalf("move %d mismatch: got %d, want %d", i, got.Moves[i], g.Moves[i])
+		}
+	}
+}
+
+func TestLoadGameRejectsOutOfRangeColumn(t *testing.T) {
+	r := strings.NewReader("Event: Test\n\n;R[7]")
+	if _, err := LoadGame(r); err == nil {
+		t.Fatalf("expected an out-of-range column to be rejected")
+	}
+
+	r = strings.NewReader("Event: Test\n\n;R[-1]")
+	if _, err := LoadGame(r); err == nil {
+		t.Fatalf("expected a negative column to be rejected")
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"solveur4/GO/game"
+	"solveur4/GO/menace"
+	"solveur4/GO/solver"
+)
+
+const thinkTime = 5 * time.Second
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "solve":
+		cmdSolve()
+	case "play":
+		cmdPlay()
+	case "analyze":
+		if len(os.Args) < 3 {
+			fmt.Println("usage: solveur4 analyze <fichier>")
+			os.Exit(1)
+		}
+		cmdAnalyze(os.Args[2])
+	case "selfplay":
+		cmdSelfplay()
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Println("usage: solveur4 <solve|play|analyze|selfplay> [args]")
+}
+
+func cmdSolve() {
+	board := solver.NewBoard()
+
+	start := time.Now()
+	move, pv, score := solver.SolveWithBudget(board, thinkTime)
+	elapsed := time.Since(start)
+
+	fmt.Printf("⏱️ Temps d'exécution de SolveWithBudget (budget %s) : %s\n", thinkTime, elapsed)
+	fmt.Printf("Meilleur coup pour P1 : colonne %d (score %d)\n", move, score)
+
+	fmt.Println("\n✅ Variante principale trouvée avant expiration du budget :")
+	if len(pv) == 0 {
+		fmt.Println("Aucune variante trouvée.")
+		return
+	}
+	for i, m := range pv {
+		player := "P1"
+		if i%2 != 0 {
+			player = "P2"
+		}
+		fmt.Printf("%s joue colonne %d\n", player, m)
+	}
+}
+
+// cmdPlay runs an interactive terminal game: the human plays Red (P1) and
+// picks columns on stdin, the engine replies as Yellow using the
+// time-budgeted negamax search.
+func cmdPlay() {
+	board := solver.NewBoard()
+	reader := bufio.NewReader(os.Stdin)
+	g := &game.Game{Event: "Partie interactive", Red: "Humain", Yellow: "Moteur"}
+
+	for {
+		printBoard(board)
+
+		if board.IsDraw() {
+			fmt.Println("Match nul.")
+			g.Result = "="
+			break
+		}
+
+		var col int
+		if board.ToMove() == solver.P1 {
+			fmt.Print("Votre colonne (0-6) : ")
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(line))
+			if err != nil || n < 0 || n >= solver.Cols || !board.CanPlay(n) {
+				fmt.Println("Coup invalide.")
+				continue
+			}
+			col = n
+		} else {
+			col, _, _ = solver.SolveWithBudget(board, thinkTime)
+			fmt.Printf("Le moteur joue la colonne %d\n", col)
+		}
+
+		won := board.IsWinningMove(col)
+		toMove := board.ToMove()
+		board = board.Play(col)
+		g.Moves = append(g.Moves, col)
+
+		if won {
+			printBoard(board)
+			if toMove == solver.P1 {
+				fmt.Println("Vous gagnez !")
+				g.Result = "R+"
+			} else {
+				fmt.Println("Le moteur gagne.")
+				g.Result = "Y+"
+			}
+			break
+		}
+	}
+
+	f, err := os.Create("last_game.c4")
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_ = game.SaveGame(f, g)
+}
+
+func printBoard(b solver.Board) {
+	for row := solver.Rows - 1; row >= 0; row-- {
+		for col := 0; col < solver.Cols; col++ {
+			switch b.At(row, col) {
+			case solver.P1:
+				fmt.Print("R ")
+			case solver.P2:
+				fmt.Print("Y ")
+			default:
+				fmt.Print(". ")
+			}
+		}
+		fmt.Println()
+	}
+}
+
+// cmdAnalyze replays a saved game record and prints, for each move, the
+// best score SolveWithBudget finds within thinkTime and whether it matched
+// the move actually played. It budgets the same way cmdSolve/cmdPlay do
+// rather than calling Solve directly: Solve's unbounded exhaustive search
+// can take minutes on early positions, which would hang analyze on the
+// first move of any real game.
+func cmdAnalyze(path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Println("erreur :", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	g, err := game.LoadGame(f)
+	if err != nil {
+		fmt.Println("erreur :", err)
+		os.Exit(1)
+	}
+
+	board := solver.NewBoard()
+	for i, col := range g.Moves {
+		if !board.CanPlay(col) {
+			fmt.Printf("erreur : coup %d de la partie joue une colonne %d déjà pleine\n", i+1, col)
+			os.Exit(1)
+		}
+
+		bestMove, _, bestScore := solver.SolveWithBudget(board, thinkTime)
+
+		player := "R"
+		if i%2 != 0 {
+			player = "Y"
+		}
+		if col == bestMove {
+			fmt.Printf("%2d. %s joue %d — meilleur coup (score %d)\n", i+1, player, col, bestScore)
+		} else {
+			fmt.Printf("%2d. %s joue %d — alternative au meilleur coup, colonne %d (score %d)\n",
+				i+1, player, col, bestMove, bestScore)
+		}
+
+		board = board.Play(col)
+	}
+}
+
+// cmdSelfplay trains a menace agent against the exact solver. The number of
+// games defaults to 1000 and can be overridden with a second argument.
+func cmdSelfplay() {
+	games := 1000
+	if len(os.Args) > 2 {
+		if n, err := strconv.Atoi(os.Args[2]); err == nil {
+			games = n
+		}
+	}
+
+	agent := menace.NewAgent()
+	agent.Train(games, func(done, total int) {
+		fmt.Printf("\r%d/%d parties jouées", done, total)
+	})
+	fmt.Println()
+	fmt.Printf("Entraînement terminé : %d parties, %d positions apprises\n", games, len(agent.Beads))
+
+	if err := agent.Save("menace.gob"); err != nil {
+		fmt.Println("erreur de sauvegarde :", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Package menace implements a MENACE-style matchbox learner for Connect-4,
+// trained by self-play against the exact solver rather than another MENACE
+// instance, in the spirit of Michie's original tic-tac-toe matchboxes.
+package menace
+
+import (
+	"encoding/gob"
+	"math/rand"
+	"os"
+	"time"
+
+	"solveur4/GO/solver"
+)
+
+// initialBeads is the starting bead count handed to every legal column the
+// first time a position is seen.
+const initialBeads = 8
+
+// opponentBudget bounds how long the "exact" opponent thinks per reply.
+// Solve's full exhaustive search can take minutes this close to the empty
+// board, which would make a single Train game — let alone the default 1000
+// — effectively hang; SolveWithBudget instead returns the best move found by
+// the deepest iteration that completed in time.
+const opponentBudget = 200 * time.Millisecond
+
+// Agent is a collection of matchboxes: one per canonical position the agent
+// has faced as the side to move, each holding a bead count per legal
+// column. Moves are sampled with probability proportional to bead counts.
+type Agent struct {
+	Beads map[uint64][]int
+	rng   *rand.Rand
+}
+
+// NewAgent returns an untrained agent with empty matchboxes.
+func NewAgent() *Agent {
+	return &Agent{
+		Beads: make(map[uint64][]int),
+		rng:   rand.New(rand.NewSource(1)),
+	}
+}
+
+// canonicalCol translates col, a column index in b's own orientation, into
+// the orientation b's bucket is actually indexed by: unchanged if b already
+// is its own canonical minimum, mirrored otherwise. Beads is keyed by
+// CanonicalKey, which folds a position and its left-right mirror into one
+// bucket, but a bead count at index c only means "column c" for whichever
+// orientation happened to create that bucket; any board on the other side
+// of the mirror has to translate through this before touching the slice.
+func canonicalCol(b solver.Board, col int) int {
+	if b.Key() == b.CanonicalKey() {
+		return col
+	}
+	return solver.Cols - 1 - col
+}
+
+func (a *Agent) boxFor(b solver.Board, cols []int) []int {
+	key := b.CanonicalKey()
+	box, ok := a.Beads[key]
+	if !ok {
+		box = make([]int, solver.Cols)
+		for _, c := range cols {
+			box[canonicalCol(b, c)] = initialBeads
+		}
+		a.Beads[key] = box
+	}
+	return box
+}
+
+// Play samples a legal column from b proportionally to its matchbox's bead
+// counts. It has the same shape as Solver.Solve's move-choosing half, so the
+// two agents can be pitted against each other in a tournament.
+func (a *Agent) Play(b solver.Board) int {
+	cols := solver.ValidColumns(b)
+	if len(cols) == 0 {
+		return -1
+	}
+	box := a.boxFor(b, cols)
+
+	total := 0
+	for _, c := range cols {
+		total += box[canonicalCol(b, c)]
+	}
+	pick := a.rng.Intn(total)
+	for _, c := range cols {
+		cc := canonicalCol(b, c)
+		if pick < box[cc] {
+			return c
+		}
+		pick -= box[cc]
+	}
+	return cols[len(cols)-1]
+}
+
+// moveRecord is one agent move kept for reinforcement after a game ends.
+// col is already translated to the orientation the bucket at key is indexed
+// by (see canonicalCol), so reinforce can index straight into Beads[key]
+// without needing the board back.
+type moveRecord struct {
+	key uint64
+	col int
+}
+
+// Train plays games self-play games against the exact solver — the agent
+// moves by sampling its matchboxes, the solver replies with the best move
+// SolveWithBudget finds within opponentBudget — alternating who starts, and
+// reinforces the matchboxes used after each game according to its outcome.
+// progress, if non-nil, is called periodically with how many of the games
+// have finished so far; at opponentBudget per engine reply, the default
+// 1000-game run takes long enough that silent progress looks hung.
+func (a *Agent) Train(games int, progress func(done, total int)) {
+	for g := 0; g < games; g++ {
+		board := solver.NewBoard()
+		agentToMove := g%2 == 0
+		var history []moveRecord
+		outcome := 0
+
+		for {
+			var col int
+			if agentToMove {
+				col = a.Play(board)
+				history = append(history, moveRecord{key: board.CanonicalKey(), col: canonicalCol(board, col)})
+			} else {
+				col, _, _ = solver.SolveWithBudget(board, opponentBudget)
+			}
+			if col == -1 {
+				break
+			}
+
+			won := board.IsWinningMove(col)
+			board = board.Play(col)
+			if won {
+				if agentToMove {
+					outcome = 1
+				} else {
+					outcome = -1
+				}
+				break
+			}
+			if board.IsDraw() {
+				break
+			}
+			agentToMove = !agentToMove
+		}
+
+		a.reinforce(history, outcome)
+
+		if progress != nil && (g+1)%10 == 0 {
+			progress(g+1, games)
+		}
+	}
+	if progress != nil {
+		progress(games, games)
+	}
+}
+
+// reinforce adds beads to every column the agent chose in history: +3 on a
+// win, +1 on a draw, -1 on a loss, clamped so a column never drops below 1
+// bead (a matchbox that runs dry can never be picked again).
+func (a *Agent) reinforce(history []moveRecord, outcome int) {
+	delta := 1
+	if outcome == 1 {
+		delta = 3
+	} else if outcome == -1 {
+		delta = -1
+	}
+	for _, m := range history {
+		box := a.Beads[m.key]
+		box[m.col] += delta
+		if box[m.col] < 1 {
+			box[m.col] = 1
+		}
+	}
+}
+
+// Save writes the agent's matchboxes to path using gob encoding.
+func (a *Agent) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return gob.NewEncoder(f).Encode(a.Beads)
+}
+
+// Load replaces the agent's matchboxes with those stored at path.
+func (a *Agent) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	beads := make(map[uint64][]int)
+	if err := gob.NewDecoder(f).Decode(&beads); err != nil {
+		return err
+	}
+	a.Beads = beads
+	return nil
+}
@@ -0,0 +1,38 @@
+package menace
+
+import (
+	"testing"
+
+	"solveur4/GO/solver"
+)
+
+// TestBoxForTranslatesMirroredColumns reinforces playing the real column 0
+// from board.Play(0), then reads the bucket back through board.Play(6),
+// its canonically-identical mirror. Beads is keyed by CanonicalKey, which
+// folds the two boards into one bucket, but "column 0" on one side is
+// physically "column 6" on the other, so the weight must show up at the
+// mirrored index rather than leaking onto the literal one.
+func TestBoxForTranslatesMirroredColumns(t *testing.T) {
+	a := NewAgent()
+	board := solver.NewBoard()
+
+	left := board.Play(0)
+	right := board.Play(6)
+	if right.CanonicalKey() != left.CanonicalKey() {
+		t.Fatalf("test setup: expected board.Play(0) and board.Play(6) to be canonical mirrors")
+	}
+	a.boxFor(left, solver.ValidColumns(left))
+
+	history := []moveRecord{{key: left.CanonicalKey(), col: canonicalCol(left, 0)}}
+	for i := 0; i < 100; i++ {
+		a.reinforce(history, 1)
+	}
+
+	box := a.boxFor(right, solver.ValidColumns(right))
+	if box[canonicalCol(right, 0)] > box[canonicalCol(right, 6)] {
+		t.Fatalf("reinforcing real column 0 from the left board leaked onto the mirror's column 0 instead of column 6: box=%v", box)
+	}
+	if box[canonicalCol(right, 6)] <= initialBeads {
+		t.Fatalf("expected reinforcement to land on the mirror board's real column 6, got box=%v", box)
+	}
+}
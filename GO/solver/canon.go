@@ -0,0 +1,34 @@
+package solver
+
+// Key returns the transposition-table key for b: current+mask, the classic
+// Connect-4 canonical key (mask alone fixes which cells are occupied, and
+// current then fixes who owns them).
+func (b Board) Key() uint64 { return b.current + b.mask }
+
+// mirroredKey returns the key of b's left-right mirror image without
+// constructing the mirrored Board.
+func (b Board) mirroredKey() uint64 {
+	return mirrorColumns(b.current) + mirrorColumns(b.mask)
+}
+
+func mirrorColumns(bits uint64) uint64 {
+	const colWidth = Rows + 1
+	var out uint64
+	for c := 0; c < Cols; c++ {
+		col := (bits >> columnBase(c)) & (uint64(1)<<colWidth - 1)
+		out |= col << columnBase(Cols-1-c)
+	}
+	return out
+}
+
+// CanonicalKey returns the smaller of b.Key() and its mirror image's key, so
+// that a position and its left-right reflection always map to the same
+// bucket. Used by the menace agent to halve the state space it has to
+// learn over.
+func (b Board) CanonicalKey() uint64 {
+	k, m := b.Key(), b.mirroredKey()
+	if m < k {
+		return m
+	}
+	return k
+}
@@ -0,0 +1,218 @@
+package solver
+
+import (
+	"sort"
+	"time"
+)
+
+// killerTable remembers, per search ply, the last two moves that caused a
+// beta cutoff there. Trying them first in sibling branches at the same ply
+// is cheap and often prunes just as well as the position's actual best move.
+type killerTable struct {
+	moves [cells + 1][2]int
+}
+
+func newKillerTable() *killerTable {
+	kt := &killerTable{}
+	for ply := range kt.moves {
+		kt.moves[ply] = [2]int{-1, -1}
+	}
+	return kt
+}
+
+func (kt *killerTable) add(ply, col int) {
+	if kt.moves[ply][0] == col {
+		return
+	}
+	kt.moves[ply][1] = kt.moves[ply][0]
+	kt.moves[ply][0] = col
+}
+
+// iterativeSearch runs a single depth-limited, time-budgeted negamax pass.
+// It keeps killer moves and history scores across the whole budget, since
+// both are cheap to accumulate and keep improving move ordering as
+// iterative deepening goes deeper.
+type iterativeSearch struct {
+	tt       *transTable
+	killers  *killerTable
+	history  [Cols]int
+	deadline time.Time
+	aborted  bool
+	nodes    uint
+}
+
+// deadlineCheckInterval is how many nodes negamax visits between calls to
+// time.Now(). It must not be keyed off ply: Connect 4 only has cells plies
+// total, so a ply-based check (e.g. "ply is a multiple of 64") would almost
+// never fire and the budget would never actually be enforced.
+const deadlineCheckInterval = 1024
+
+// orderMoves sorts cols (already center-prioritized) by history score,
+// promoting the previous iteration's PV move and this ply's killer moves to
+// the front. sort.SliceStable keeps prioritizeCenter's order as the
+// last-resort tiebreak between equally-scored columns.
+func (is *iterativeSearch) orderMoves(cols []int, ply, pvMove int) []int {
+	ordered := append([]int(nil), cols...)
+	score := func(col int) int {
+		s := is.history[col]
+		if col == pvMove {
+			s += 1 << 20
+		}
+		if col == is.killers.moves[ply][0] || col == is.killers.moves[ply][1] {
+			s += 1 << 10
+		}
+		return s
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return score(ordered[i]) > score(ordered[j]) })
+	return ordered
+}
+
+// negamax searches b to the given depth (in plies), returning the score
+// from the point of view of the side to move and, via line, the sequence of
+// moves that achieves it. It aborts as soon as the time budget is spent,
+// at which point its return value must be discarded by the caller.
+func (is *iterativeSearch) negamax(b Board, alpha, beta, depth, ply, pvMove int, line *[]int) int {
+	if is.aborted {
+		return 0
+	}
+	is.nodes++
+	if is.nodes%deadlineCheckInterval == 0 && time.Now().After(is.deadline) {
+		is.aborted = true
+		return 0
+	}
+
+	cols := validColumns(b)
+	for _, c := range cols {
+		if b.IsWinningMove(c) {
+			*line = []int{c}
+			return (cells + 1 - b.moves) / 2
+		}
+	}
+	if b.IsDraw() {
+		*line = nil
+		return 0
+	}
+	if depth == 0 {
+		// No positional evaluator yet: treat an unresolved cutoff as
+		// balanced rather than guessing. Deeper iterations correct this
+		// as the budget allows.
+		*line = nil
+		return 0
+	}
+
+	key := b.Key()
+	if entry, ok := is.tt.probe(key); ok && int(entry.depth) >= depth {
+		switch entry.bound {
+		case exact:
+			return int(entry.value)
+		case lower:
+			if int(entry.value) > alpha {
+				alpha = int(entry.value)
+			}
+		case upper:
+			if int(entry.value) < beta {
+				beta = int(entry.value)
+			}
+		}
+		if alpha >= beta {
+			return int(entry.value)
+		}
+	}
+
+	best := alpha
+	var bestLine []int
+	for _, c := range is.orderMoves(prioritizeCenter(cols), ply, pvMove) {
+		var childLine []int
+		score := -is.negamax(b.Play(c), -beta, -best, depth-1, ply+1, -1, &childLine)
+		if is.aborted {
+			return 0
+		}
+		if score > best {
+			best = score
+			bestLine = append([]int{c}, childLine...)
+		}
+		if score >= beta {
+			is.killers.add(ply, c)
+			is.history[c] += depth * depth
+			is.tt.store(key, depth, score, lower)
+			*line = bestLine
+			return score
+		}
+	}
+
+	bound := exact
+	if best <= alpha {
+		bound = upper
+	}
+	is.tt.store(key, depth, best, bound)
+	*line = bestLine
+	return best
+}
+
+// SolveWithBudget searches board for up to budget, deepening one ply at a
+// time (iterative deepening) instead of Solve's fixed full-depth search. It
+// returns the best move and principal variation found by the deepest
+// iteration that completed before the budget expired, which makes the
+// solver usable interactively instead of only in the all-or-nothing
+// exhaustive mode.
+func SolveWithBudget(board Board, budget time.Duration) (bestMove int, pv []int, score int) {
+	is := &iterativeSearch{
+		tt:       newTransTable(1 << 20),
+		killers:  newKillerTable(),
+		deadline: time.Now().Add(budget),
+	}
+
+	bestMove = -1
+	remaining := cells - board.Moves()
+	for depth := 1; depth <= remaining; depth++ {
+		pvMove := -1
+		if len(pv) > 0 {
+			pvMove = pv[0]
+		}
+
+		alpha, beta := -cells, cells
+		best := alpha
+		iterMove := -1
+		var iterPV []int
+
+		for _, c := range is.orderMoves(prioritizeCenter(validColumns(board)), 0, pvMove) {
+			var childLine []int
+			var childScore int
+			if board.IsWinningMove(c) {
+				childScore, childLine = (cells+1-board.moves)/2, nil
+			} else {
+				childScore = -is.negamax(board.Play(c), -beta, -best, depth-1, 1, -1, &childLine)
+			}
+			if is.aborted {
+				break
+			}
+			if iterMove == -1 || childScore > best {
+				best = childScore
+				iterMove = c
+				iterPV = append([]int{c}, childLine...)
+			}
+		}
+
+		if is.aborted {
+			break
+		}
+		bestMove, pv, score = iterMove, iterPV, best
+	}
+
+	return bestMove, pv, score
+}
+
+// SolveWithBudgetAndBook behaves like SolveWithBudget, except it first
+// consults book (as loaded by the book package): a hit returns the
+// precomputed move and score instantly, the same way Solver.Solve does,
+// instead of re-deriving an already-solved position under a time budget. A
+// miss falls straight through to SolveWithBudget. book may be nil, in which
+// case this is exactly SolveWithBudget.
+func SolveWithBudgetAndBook(board Board, budget time.Duration, book map[uint64]BookEntry) (bestMove int, pv []int, score int) {
+	if book != nil {
+		if entry, ok := book[board.Key()]; ok {
+			return int(entry.BestMove), nil, int(entry.Score)
+		}
+	}
+	return SolveWithBudget(board, budget)
+}
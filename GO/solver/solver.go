@@ -0,0 +1,471 @@
+// Package solver implements an exact Connect-4 engine on top of a bitboard
+// position encoding (the classic 7x7 "stacked columns" layout, one sentinel
+// bit per column). It replaces the earlier tree-exploration prototypes
+// (exploreGameTree / exploreGameTreeSmart) which cloned the whole grid on
+// every move and could not see past a handful of plies.
+package solver
+
+import (
+	"math/bits"
+	"sort"
+)
+
+const (
+	Cols  = 7
+	Rows  = 6
+	cells = Cols * Rows
+)
+
+// Player identifies which side is to move. Boards themselves don't store an
+// absolute color: current always holds the stones of the side to move, so a
+// Player only matters when printing a position or tagging a move history.
+type Player int8
+
+const (
+	None Player = 0
+	P1   Player = 1
+	P2   Player = 2
+)
+
+// opponent returns the other player.
+func opponent(p Player) Player {
+	if p == P1 {
+		return P2
+	}
+	return P1
+}
+
+// Board is a Connect-4 position encoded as two 64-bit masks: mask has a bit
+// set on every occupied cell, current has a bit set on every cell owned by
+// the side to move. Each of the 7 columns occupies 7 consecutive bits (6
+// playable rows plus one permanently-empty sentinel bit), so a column's
+// stones never overflow into its neighbour's bits.
+type Board struct {
+	current uint64
+	mask    uint64
+	moves   int
+}
+
+// NewBoard returns the empty starting position.
+func NewBoard() Board { return Board{} }
+
+func columnBase(col int) uint   { return uint(col * (Rows + 1)) }
+func bottomMask(col int) uint64 { return uint64(1) << columnBase(col) }
+func topMask(col int) uint64    { return uint64(1) << (columnBase(col) + Rows - 1) }
+func columnMask(col int) uint64 { return ((uint64(1) << Rows) - 1) << columnBase(col) }
+
+// CanPlay reports whether col still has room for a stone.
+func (b Board) CanPlay(col int) bool { return b.mask&topMask(col) == 0 }
+
+// IsWinningMove reports whether dropping a stone for the side to move in col
+// completes a four-in-a-row, without actually playing the move.
+func (b Board) IsWinningMove(col int) bool {
+	pos := b.current | ((b.mask + bottomMask(col)) & columnMask(col))
+	return alignment(pos)
+}
+
+// Play returns the position after the side to move drops a stone in col.
+// Boards are small value types, so positions are threaded through search by
+// copying rather than cloning a grid and undoing moves.
+func (b Board) Play(col int) Board {
+	b.current ^= b.mask
+	b.mask |= b.mask + bottomMask(col)
+	b.moves++
+	return b
+}
+
+// Moves returns the number of plies already played.
+func (b Board) Moves() int { return b.moves }
+
+// IsDraw reports whether the board is full with no winner.
+func (b Board) IsDraw() bool { return b.moves == cells }
+
+// ToMove returns the side to move, assuming P1 opens the game.
+func (b Board) ToMove() Player {
+	if b.moves%2 == 0 {
+		return P1
+	}
+	return P2
+}
+
+// At returns which player owns the stone at (row, col), or None if the cell
+// is empty. Intended for display: search code works directly with the
+// bitmaps instead.
+func (b Board) At(row, col int) Player {
+	bit := uint64(1) << (columnBase(col) + uint(row))
+	if b.mask&bit == 0 {
+		return None
+	}
+	if b.current&bit != 0 {
+		return b.ToMove()
+	}
+	return opponent(b.ToMove())
+}
+
+// alignment reports whether pos (a single side's stone bitmap) contains a
+// four-in-a-row, checked along all four directions in constant time.
+func alignment(pos uint64) bool {
+	// Horizontal.
+	m := pos & (pos >> 7)
+	if m&(m>>14) != 0 {
+		return true
+	}
+	// Diagonal "\".
+	m = pos & (pos >> 6)
+	if m&(m>>12) != 0 {
+		return true
+	}
+	// Diagonal "/".
+	m = pos & (pos >> 8)
+	if m&(m>>16) != 0 {
+		return true
+	}
+	// Vertical.
+	m = pos & (pos >> 1)
+	if m&(m>>2) != 0 {
+		return true
+	}
+	return false
+}
+
+func validColumns(b Board) []int {
+	cols := make([]int, 0, Cols)
+	for c := 0; c < Cols; c++ {
+		if b.CanPlay(c) {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// ValidColumns returns the legal columns for b, for callers outside the
+// package (the menace and book subpackages) that need to enumerate
+// moves themselves.
+func ValidColumns(b Board) []int { return validColumns(b) }
+
+// prioritizeCenter reorders cols so that columns closer to the center come
+// first; central columns take part in more alignments, so trying them first
+// prunes alpha-beta branches earlier. Carried over from the pre-bitboard
+// solver, which relied on the same ordering.
+func prioritizeCenter(cols []int) []int {
+	center := Cols / 2
+	sorted := make([]int, len(cols))
+	copy(sorted, cols)
+	for i := 0; i < len(sorted)-1; i++ {
+		for j := i + 1; j < len(sorted); j++ {
+			if abs(center-sorted[j]) < abs(center-sorted[i]) {
+				sorted[i], sorted[j] = sorted[j], sorted[i]
+			}
+		}
+	}
+	return sorted
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// cellsMaskAll has a bit set on every playable cell on the board (every
+// row of every column, but none of the per-column sentinel bits) — the
+// universe threatMask restricts its result to.
+var cellsMaskAll = func() uint64 {
+	var m uint64
+	for c := 0; c < Cols; c++ {
+		m |= columnMask(c)
+	}
+	return m
+}()
+
+// threatMask returns, for a single side's stone bitmap pos, every cell
+// (occupied or not) that would complete a four-in-a-row if it belonged to
+// pos. It runs the same four direction/shift pairs alignment uses (1, 7, 6,
+// 8) but, instead of asking "are all four bits already set", it looks for
+// windows where exactly three of the four are set and reports the
+// remaining one — the classic bit-parallel "winning positions" trick; see
+// https://github.com/PascalPons/connect4 for the reference writeup.
+func threatMask(pos uint64) uint64 {
+	var r uint64
+	for _, s := range [...]uint{1, 7, 6, 8} {
+		p := (pos << s) & (pos << (2 * s))
+		r |= p & (pos << (3 * s))
+		r |= p & (pos >> s)
+		p = (pos >> s) & (pos >> (2 * s))
+		r |= p & (pos << s)
+		r |= p & (pos >> (3 * s))
+	}
+	return r
+}
+
+// threatCount scores child (a position just played into) by how many empty
+// cells would complete a four-in-a-row for whoever just moved. It's a
+// static stand-in for "how promising was that move" that costs a handful of
+// shifts, used to order moves before searching any of them.
+func threatCount(child Board) int {
+	justMoved := child.mask &^ child.current
+	empty := cellsMaskAll &^ child.mask
+	return bits.OnesCount64(threatMask(justMoved) & empty)
+}
+
+// orderedMove is a legal move paired with the board it reaches, so that
+// negamax/Solve's move loop never has to call Play twice for the same
+// column: once here to score it, once more to actually search it.
+type orderedMove struct {
+	col   int
+	child Board
+}
+
+// orderByThreats ranks cols (the legal moves from b) by threatCount of the
+// position each one reaches, most threats first, falling back to
+// prioritizeCenter's center-distance order to break ties. Center-only
+// ordering is a poor proxy for move strength once alpha-beta needs its
+// first few branches to actually be the best ones for pruning to pay off;
+// this is what makes negamax and Solve's PVS re-searches cheap instead of
+// rare.
+func orderByThreats(b Board, cols []int) []orderedMove {
+	var score [Cols]int
+	moves := make([]orderedMove, len(cols))
+	for i, c := range prioritizeCenter(cols) {
+		child := b.Play(c)
+		score[c] = threatCount(child)
+		moves[i] = orderedMove{col: c, child: child}
+	}
+	sort.SliceStable(moves, func(i, j int) bool { return score[moves[i].col] > score[moves[j].col] })
+	return moves
+}
+
+// boundType tags what a transposition table entry actually guarantees about
+// a position's value, since alpha-beta search only ever proves a bound
+// unless the full window is searched.
+type boundType int8
+
+const (
+	exact boundType = iota
+	lower
+	upper
+)
+
+type ttEntry struct {
+	key   uint64
+	depth int8
+	value int16
+	bound boundType
+}
+
+// transTable is a fixed-size, always-replace transposition table. Entries
+// are keyed on current+mask, the standard Connect-4 canonical key: it is
+// injective because mask alone recovers which cells are occupied, and
+// current then recovers who owns them.
+type transTable struct {
+	entries []ttEntry
+}
+
+func newTransTable(size int) *transTable {
+	return &transTable{entries: make([]ttEntry, size)}
+}
+
+// mixKey scrambles key before it's used to pick a slot. current/mask never
+// carry between a column's 7-bit field and the next, so the raw key's
+// low-order bits are a function of only the first few columns and would
+// send huge numbers of positions that only differ in later columns to the
+// same always-replace slot. Multiplying by a large odd constant (the
+// splitmix64 multiplier) spreads every input bit across the whole output
+// before we reduce it to a slot index.
+func mixKey(key uint64) uint64 { return key * 0x9E3779B97F4A7C15 }
+
+func (t *transTable) index(key uint64) int { return int(mixKey(key) % uint64(len(t.entries))) }
+
+func (t *transTable) probe(key uint64) (ttEntry, bool) {
+	e := t.entries[t.index(key)]
+	if e.key == key {
+		return e, true
+	}
+	return ttEntry{}, false
+}
+
+func (t *transTable) store(key uint64, depth, value int, bound boundType) {
+	t.entries[t.index(key)] = ttEntry{key: key, depth: int8(depth), value: int16(value), bound: bound}
+}
+
+// BookEntry is a cached exact result for a position: the best column to
+// play and its score, in the same convention as Solve's return values.
+// Defined here rather than in the book package so that solver has no
+// dependency on book — book depends on solver (it runs full searches to
+// generate entries), and a dependency the other way round would cycle.
+type BookEntry struct {
+	BestMove int8
+	Score    int8
+}
+
+// Solver holds the state shared across a search: the transposition table,
+// kept alive across calls so repeated Solve/PrincipalVariation calls on
+// related positions reuse earlier work, and an optional opening book
+// consulted before falling back to search.
+type Solver struct {
+	tt   *transTable
+	book map[uint64]BookEntry
+}
+
+// NewSolver returns a ready-to-use Solver with a fresh transposition table
+// and no opening book.
+func NewSolver() *Solver {
+	return &Solver{tt: newTransTable(1 << 23)}
+}
+
+// SetBook installs a precomputed opening book: Solve consults it first and
+// only falls back to search on a miss.
+func (s *Solver) SetBook(entries map[uint64]BookEntry) { s.book = entries }
+
+// negamax returns the score of b from the point of view of the side to
+// move, searched exactly to the end of the game. A positive score means the
+// side to move wins, scored so that winning sooner is worth more than
+// winning later: (cells+1-plies)/2 at the winning ply. Every node, not just
+// the root, is checked against the opening book first, so a search that
+// descends into book territory returns immediately instead of resolving the
+// rest of the game from scratch.
+func (s *Solver) negamax(b Board, alpha, beta int) int {
+	cols := validColumns(b)
+	for _, c := range cols {
+		if b.IsWinningMove(c) {
+			return (cells + 1 - b.moves) / 2
+		}
+	}
+	if b.IsDraw() {
+		return 0
+	}
+
+	key := b.Key()
+	if s.book != nil {
+		if entry, ok := s.book[key]; ok {
+			return int(entry.Score)
+		}
+	}
+	if entry, ok := s.tt.probe(key); ok {
+		switch entry.bound {
+		case exact:
+			return int(entry.value)
+		case lower:
+			if int(entry.value) > alpha {
+				alpha = int(entry.value)
+			}
+		case upper:
+			if int(entry.value) < beta {
+				beta = int(entry.value)
+			}
+		}
+		if alpha >= beta {
+			return int(entry.value)
+		}
+	}
+
+	// No move played this turn can score higher than winning on the very
+	// next ply, so tighten beta accordingly before recursing.
+	if max := (cells - 1 - b.moves) / 2; beta > max {
+		beta = max
+		if alpha >= beta {
+			return beta
+		}
+	}
+
+	best := alpha
+	for i, m := range orderByThreats(b, cols) {
+		child := m.child
+		var score int
+		if i == 0 {
+			// First child (the move orderByThreats trusts most): search it
+			// with the full window, since it sets the bound everything else
+			// is merely checked against.
+			score = -s.negamax(child, -beta, -best)
+		} else {
+			// Every later sibling only needs to prove "not better than
+			// best", a single-point (null) window. That's far cheaper to
+			// refute than a full re-search, and refuting it is the common
+			// case once the first child is well-ordered. Only a sibling
+			// that actually beats best pays for a full-window re-search.
+			score = -s.negamax(child, -best-1, -best)
+			if score > best && score < beta {
+				score = -s.negamax(child, -beta, -score)
+			}
+		}
+		if score >= beta {
+			s.tt.store(key, 0, score, lower)
+			return score
+		}
+		if score > best {
+			best = score
+		}
+	}
+
+	bound := exact
+	if best <= alpha {
+		bound = upper
+	}
+	s.tt.store(key, 0, best, bound)
+	return best
+}
+
+// Solve returns the best column to play from b and its exact score from the
+// point of view of the side to move (positive: winning, 0: drawn, negative:
+// losing), assuming perfect play from both sides.
+func (s *Solver) Solve(b Board) (bestMove int, score int) {
+	if s.book != nil {
+		if entry, ok := s.book[b.Key()]; ok {
+			return int(entry.BestMove), int(entry.Score)
+		}
+	}
+
+	bestMove = -1
+	alpha, beta := -cells, cells
+	best := alpha
+	cols := validColumns(b)
+	for _, m := range orderByThreats(b, cols) {
+		c := m.col
+		var childScore int
+		switch {
+		case b.IsWinningMove(c):
+			childScore = (cells + 1 - b.moves) / 2
+		case bestMove == -1:
+			// First (and so far only) candidate: search with the full
+			// window to establish a real bound.
+			childScore = -s.negamax(m.child, -beta, -best)
+		default:
+			// Same null-window-then-reverify trick as negamax's own move
+			// loop: most siblings just need to confirm they're no better
+			// than best, which a 1-point window proves far more cheaply
+			// than a full re-search.
+			childScore = -s.negamax(m.child, -best-1, -best)
+			if childScore > best && childScore < beta {
+				childScore = -s.negamax(m.child, -beta, -childScore)
+			}
+		}
+		if bestMove == -1 || childScore > best {
+			best = childScore
+			bestMove = c
+		}
+	}
+	return bestMove, best
+}
+
+// PrincipalVariation replays Solve from b until the game ends, returning the
+// sequence of perfect-play moves. It supersedes the old
+// findShortestWinningPath: rather than rebuilding a full game tree and
+// searching it for the shortest win, the PV of the exact solver already is
+// that shortest forced line.
+func (s *Solver) PrincipalVariation(b Board) []int {
+	var pv []int
+	for !b.IsDraw() {
+		move, _ := s.Solve(b)
+		if move == -1 {
+			break
+		}
+		pv = append(pv, move)
+		won := b.IsWinningMove(move)
+		b = b.Play(move)
+		if won {
+			break
+		}
+	}
+	return pv
+}
@@ -0,0 +1,150 @@
+package solver
+
+import (
+	"testing"
+	"time"
+)
+
+// bitAt returns the bit for (col, row) in the encoding alignment/Play
+// operate on, independent of any Board helper, so these tests pin the raw
+// bit-shift math rather than round-tripping through Play.
+func bitAt(col, row int) uint64 { return uint64(1) << (columnBase(col) + uint(row)) }
+
+func TestAlignmentHorizontal(t *testing.T) {
+	four := bitAt(0, 0) | bitAt(1, 0) | bitAt(2, 0) | bitAt(3, 0)
+	if !alignment(four) {
+		t.Fatalf("expected four in a row horizontally to align")
+	}
+	gap := bitAt(0, 0) | bitAt(1, 0) | bitAt(3, 0) | bitAt(4, 0)
+	if alignment(gap) {
+		t.Fatalf("three stones with a gap should not align horizontally")
+	}
+}
+
+func TestAlignmentVertical(t *testing.T) {
+	four := bitAt(0, 0) | bitAt(0, 1) | bitAt(0, 2) | bitAt(0, 3)
+	if !alignment(four) {
+		t.Fatalf("expected four in a row vertically to align")
+	}
+	gap := bitAt(0, 0) | bitAt(0, 1) | bitAt(0, 3) | bitAt(0, 4)
+	if alignment(gap) {
+		t.Fatalf("three stones with a gap should not align vertically")
+	}
+}
+
+func TestAlignmentDiagonalUp(t *testing.T) {
+	four := bitAt(0, 0) | bitAt(1, 1) | bitAt(2, 2) | bitAt(3, 3)
+	if !alignment(four) {
+		t.Fatalf("expected four in a row on the bottom-left to top-right diagonal to align")
+	}
+	broken := bitAt(0, 0) | bitAt(1, 1) | bitAt(2, 3) | bitAt(3, 4)
+	if alignment(broken) {
+		t.Fatalf("a broken diagonal should not align")
+	}
+}
+
+func TestAlignmentDiagonalDown(t *testing.T) {
+	four := bitAt(0, 3) | bitAt(1, 2) | bitAt(2, 1) | bitAt(3, 0)
+	if !alignment(four) {
+		t.Fatalf("expected four in a row on the top-left to bottom-right diagonal to align")
+	}
+	broken := bitAt(0, 3) | bitAt(1, 2) | bitAt(2, 0) | bitAt(3, 0)
+	if alignment(broken) {
+		t.Fatalf("a broken diagonal should not align")
+	}
+}
+
+func TestPlayFillsColumnAndCanPlayRejectsOverflow(t *testing.T) {
+	b := NewBoard()
+	for row := 0; row < Rows; row++ {
+		if !b.CanPlay(3) {
+			t.Fatalf("column 3 should still have room at row %d", row)
+		}
+		b = b.Play(3)
+	}
+	if b.CanPlay(3) {
+		t.Fatalf("column 3 should be full after %d plies", Rows)
+	}
+	for c := 0; c < Cols; c++ {
+		if c == 3 {
+			continue
+		}
+		if !b.CanPlay(c) {
+			t.Fatalf("column %d should be untouched and still playable", c)
+		}
+	}
+}
+
+func TestKeyAndCanonicalKeyMirroring(t *testing.T) {
+	left := NewBoard().Play(0)
+	right := NewBoard().Play(6)
+
+	if left.Key() == right.Key() {
+		t.Fatalf("column 0 and column 6 openings should have distinct raw keys")
+	}
+	if left.CanonicalKey() != right.CanonicalKey() {
+		t.Fatalf("column 0 and column 6 openings are mirror images and should share a canonical key")
+	}
+
+	center := NewBoard().Play(Cols / 2)
+	if center.CanonicalKey() != center.Key() {
+		t.Fatalf("a symmetric board should be its own mirror: key %d canonical %d", center.Key(), center.CanonicalKey())
+	}
+}
+
+// TestSolveWithBudgetAndBookReturnsBookEntryInstantly plants an
+// out-of-range score at the root (one no real search could ever produce)
+// so the only way it can come back is if the budgeted path actually
+// consults the book instead of always falling through to SolveWithBudget.
+// A budget of 0 keeps any fallback search to its shallowest iterations,
+// which cannot produce that score, so only a book hit can pass.
+func TestSolveWithBudgetAndBookReturnsBookEntryInstantly(t *testing.T) {
+	board := NewBoard()
+	book := map[uint64]BookEntry{board.Key(): {BestMove: 3, Score: 42}}
+
+	move, _, score := SolveWithBudgetAndBook(board, 0, book)
+	if move != 3 || score != 42 {
+		t.Fatalf("expected the planted book entry to short-circuit the search, got move %d score %d", move, score)
+	}
+}
+
+func TestSolveWithBudgetAndBookFallsBackOnMiss(t *testing.T) {
+	board := NewBoard()
+	move, _, _ := SolveWithBudgetAndBook(board, 10*time.Millisecond, nil)
+	if move < 0 || move >= Cols {
+		t.Fatalf("expected a fallback search to return a legal column, got %d", move)
+	}
+}
+
+func TestThreatMaskFindsCompletingCell(t *testing.T) {
+	three := bitAt(0, 0) | bitAt(0, 1) | bitAt(0, 2)
+	spots := threatMask(three)
+	if spots&bitAt(0, 3) == 0 {
+		t.Fatalf("expected the cell completing the vertical three to be flagged as a threat, mask %064b", spots)
+	}
+}
+
+func TestThreatMaskIgnoresUnrelatedStones(t *testing.T) {
+	scattered := bitAt(0, 0) | bitAt(2, 3) | bitAt(5, 1)
+	if threatMask(scattered) != 0 {
+		t.Fatalf("three stones with no shared line should flag no threats, got %064b", threatMask(scattered))
+	}
+}
+
+// TestOrderByThreatsRanksTheBuildingMoveFirst sets up two P1 stones already
+// stacked in column 0 (far from center) and two P2 stones stacked in column 6
+// (also far from center, same distance), with P1 to move. Playing column 0 a third time opens a
+// threat (three stacked, one more wins); playing column 6 doesn't. Threat
+// count must be the primary sort key, not merely a tiebreak alongside
+// prioritizeCenter, which alone can't distinguish two equally off-center
+// columns.
+func TestOrderByThreatsRanksTheBuildingMoveFirst(t *testing.T) {
+	b := NewBoard().Play(0).Play(6).Play(0).Play(6)
+	ordered := orderByThreats(b, validColumns(b))
+	if ordered[0].col != 0 {
+		t.Fatalf("expected column 0 (which builds a vertical threat) to be ordered first, got %v", ordered)
+	}
+	if ordered[0].child != b.Play(0) {
+		t.Fatalf("expected the ordered move's child board to match playing its column")
+	}
+}